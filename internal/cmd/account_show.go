@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/chiselstrike/iku-turso-cli/internal/turso"
 	"golang.org/x/sync/errgroup"
+	"sync"
 	"time"
 
 	"github.com/chiselstrike/iku-turso-cli/internal"
@@ -15,6 +16,8 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var showAllProfiles bool
+
 var accountShowCmd = &cobra.Command{
 	Use:               "show",
 	Short:             "Show your current account plan.",
@@ -27,86 +30,165 @@ var accountShowCmd = &cobra.Command{
 			return err
 		}
 
-		client, err := createTursoClientFromAccessToken(true)
-		if err != nil {
-			return err
+		if showAllProfiles {
+			return showAccountsForAllProfiles(settings)
 		}
 
-		databases, err := client.Databases.List()
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		summary, err := fetchAccountSummary(ctx, settings, activeProfileName(settings))
 		if err != nil {
 			return err
 		}
+		printAccountSummary(summary)
+		return nil
+	},
+}
 
-		numDatabases := len(databases)
-		numLocations := 0
-		inspectRet := InspectInfo{}
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
-		// FIXME: this should be done at the server so we can enforce it
-		var dbTokens []string
-		var dbInstances [][]turso.Instance
-		var instanceCount int
-		for _, database := range databases {
-			numLocations += len(database.Regions)
-			instances, err := client.Instances.List(database.Name)
-			if err != nil {
-				return err
-			}
+func init() {
+	accountShowCmd.Flags().BoolVar(&showAllProfiles, "all", false, "Show usage across every configured profile.")
+}
 
-			token, err := client.Databases.Token(database.Name, "1d", true)
-			if err != nil {
-				return err
-			}
+// accountSummary is everything accountShowCmd prints for one profile.
+type accountSummary struct {
+	profile      string
+	numDatabases int
+	numLocations int
+	inspect      InspectInfo
+}
 
-			dbTokens = append(dbTokens, token)
-			instanceCount += len(instances)
-			dbInstances = append(dbInstances, instances)
-		}
-		inspectResCh := make(chan *InspectInfo, instanceCount)
-		g, ctx := errgroup.WithContext(ctx)
-		for idx, database := range databases {
-			idx := idx
-			database := database
-			for _, instance := range dbInstances[idx] {
-				instance := instance
-				g.Go(func() error {
-					url := getInstanceHttpUrl(settings, &database, &instance)
-					ret, err := inspect(ctx, url, dbTokens[idx], instance.Region, false)
-					if err != nil {
-						return err
-					}
-					inspectResCh <- ret
-					return nil
-				})
-			}
+// fetchAccountSummary gathers the usage numbers for a single profile. It's
+// shared between the single-account path and `--all`, which calls it once
+// per profile concurrently.
+func fetchAccountSummary(ctx context.Context, s *settings.Settings, profile string) (*accountSummary, error) {
+	client, err := createTursoClientFromAccessTokenForProfile(s, profile, true)
+	if err != nil {
+		return nil, err
+	}
+
+	databases, err := client.Databases.List()
+	if err != nil {
+		return nil, err
+	}
+
+	numDatabases := len(databases)
+	numLocations := 0
+	inspectRet := InspectInfo{}
+	// FIXME: this should be done at the server so we can enforce it
+	var dbTokens []string
+	var dbInstances [][]turso.Instance
+	var instanceCount int
+	for _, database := range databases {
+		numLocations += len(database.Regions)
+		instances, err := client.Instances.List(database.Name)
+		if err != nil {
+			return nil, err
 		}
-		if err := g.Wait(); err != nil {
-			return err
+
+		token, err := client.Databases.Token(database.Name, "1d", true)
+		if err != nil {
+			return nil, err
 		}
-		for i := 0; i < instanceCount; i++ {
-			ret := <-inspectResCh
-			inspectRet.Accumulate(ret)
+
+		dbTokens = append(dbTokens, token)
+		instanceCount += len(instances)
+		dbInstances = append(dbInstances, instances)
+	}
+	inspectResCh := make(chan *InspectInfo, instanceCount)
+	g, ctx := errgroup.WithContext(ctx)
+	for idx, database := range databases {
+		idx := idx
+		database := database
+		for _, instance := range dbInstances[idx] {
+			instance := instance
+			g.Go(func() error {
+				url := getInstanceHttpUrl(s, &database, &instance)
+				ret, err := inspect(ctx, url, dbTokens[idx], instance.Region, false)
+				if err != nil {
+					return err
+				}
+				inspectResCh <- ret
+				return nil
+			})
 		}
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	for i := 0; i < instanceCount; i++ {
+		ret := <-inspectResCh
+		inspectRet.Accumulate(ret)
+	}
 
-		fmt.Printf("You are currently on %s plan.\n", internal.Emph("starter"))
-		fmt.Println()
+	return &accountSummary{profile: profile, numDatabases: numDatabases, numLocations: numLocations, inspect: inspectRet}, nil
+}
 
-		columns := make([]interface{}, 0)
-		columns = append(columns, "RESOURCE")
-		columns = append(columns, "USED")
-		columns = append(columns, "MAX")
+func printAccountSummary(summary *accountSummary) {
+	fmt.Printf("You are currently on %s plan.\n", internal.Emph("starter"))
+	fmt.Println()
 
-		tbl := table.New(columns...)
+	columns := make([]interface{}, 0)
+	columns = append(columns, "RESOURCE")
+	columns = append(columns, "USED")
+	columns = append(columns, "MAX")
 
-		columnFmt := color.New(color.FgBlue, color.Bold).SprintfFunc()
-		tbl.WithFirstColumnFormatter(columnFmt)
+	tbl := table.New(columns...)
 
-		tbl.AddRow("storage", inspectRet.PrintTotal(), humanize.IBytes(8*1024*1024*1024))
-		tbl.AddRow("rows read", inspectRet.RowsReadCount, fmt.Sprintf("%d", int(1e9)))
-		tbl.AddRow("databases", numDatabases, "3")
-		tbl.AddRow("locations", numLocations, "3")
-		tbl.Print()
+	columnFmt := color.New(color.FgBlue, color.Bold).SprintfFunc()
+	tbl.WithFirstColumnFormatter(columnFmt)
 
-		return nil
-	},
+	tbl.AddRow("storage", summary.inspect.PrintTotal(), humanize.IBytes(8*1024*1024*1024))
+	tbl.AddRow("rows read", summary.inspect.RowsReadCount, fmt.Sprintf("%d", int(1e9)))
+	tbl.AddRow("databases", summary.numDatabases, "3")
+	tbl.AddRow("locations", summary.numLocations, "3")
+	tbl.Print()
+}
+
+// showAccountsForAllProfiles fetches every profile's usage concurrently and
+// prints one table per account. Unlike fetchAccountSummary's own fan-out
+// across a single profile's instances, profiles are independent of each
+// other: a logged-out or expired profile shouldn't stop us from showing the
+// ones that did succeed, so each profile's error is collected rather than
+// aborting the whole command.
+func showAccountsForAllProfiles(s *settings.Settings) error {
+	profiles := s.ListProfileNames()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	summaries := make([]*accountSummary, len(profiles))
+	errs := make([]error, len(profiles))
+	var wg sync.WaitGroup
+	for idx, profile := range profiles {
+		idx := idx
+		profile := profile
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			summary, err := fetchAccountSummary(ctx, s, profile)
+			if err != nil {
+				errs[idx] = fmt.Errorf("profile %s: %w", profile, err)
+				return
+			}
+			summaries[idx] = summary
+		}()
+	}
+	wg.Wait()
+
+	ok := false
+	for idx, summary := range summaries {
+		if err := errs[idx]; err != nil {
+			fmt.Printf("Profile %s: %s\n\n", internal.Emph(profiles[idx]), err)
+			continue
+		}
+		ok = true
+		fmt.Printf("Profile %s:\n", internal.Emph(summary.profile))
+		printAccountSummary(summary)
+		fmt.Println()
+	}
+	if !ok {
+		return fmt.Errorf("could not fetch account usage for any profile")
+	}
+	return nil
 }
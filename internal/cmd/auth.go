@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"bufio"
 	"context"
 	_ "embed"
 	"encoding/json"
@@ -9,7 +10,9 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"os"
 	"strconv"
+	"strings"
 	"text/template"
 
 	"github.com/chiselstrike/iku-turso-cli/internal/settings"
@@ -27,6 +30,9 @@ var authCmd = &cobra.Command{
 	ValidArgsFunction: noSpaceArg,
 }
 
+var headlessLogin bool
+var deviceLogin bool
+
 var loginCmd = &cobra.Command{
 	Use:               "login",
 	Short:             "Login to the platform.",
@@ -35,6 +41,8 @@ var loginCmd = &cobra.Command{
 	RunE:              login,
 }
 
+var logoutRevokeApiToken string
+
 var logoutCmd = &cobra.Command{
 	Use:               "logout",
 	Short:             "Log out currently logged in user.",
@@ -54,8 +62,8 @@ var tokenCmd = &cobra.Command{
 		if err != nil {
 			return fmt.Errorf("could not retrieve local config: %w", err)
 		}
-		token := settings.GetToken()
-		if !isJwtTokenValid(token) {
+		token := settings.GetToken(activeProfileName(settings))
+		if !isLoggedIn() {
 			return fmt.Errorf("no user logged in. Run `turso auth login` to log in and get a token")
 		}
 		fmt.Println(token)
@@ -64,17 +72,26 @@ var tokenCmd = &cobra.Command{
 }
 
 func init() {
+	loginCmd.Flags().BoolVar(&headlessLogin, "headless", false, "Print the login URL instead of opening it in a browser, and read the resulting token from stdin. Useful when logging in over SSH.")
+	loginCmd.Flags().BoolVar(&deviceLogin, "device", false, "Log in using a one-time code entered on another device, without opening a local browser or port. Useful on headless servers and in containers.")
+	logoutCmd.Flags().StringVar(&logoutRevokeApiToken, "api-token", "", "Also revoke this minted API token on the server.")
+
 	rootCmd.AddCommand(authCmd)
 	authCmd.AddCommand(loginCmd)
 	authCmd.AddCommand(logoutCmd)
 	authCmd.AddCommand(tokenCmd)
 }
 
-func isJwtTokenValid(token string) bool {
+// isJwtTokenValid asks the platform whether token is still good. It's the
+// one place we still pay a round trip instead of trusting the
+// locally-parsed expiry, since logging in/out should always reflect the
+// server's view of the world — except when there's no token at all, which
+// is answered locally instead of sent to the server as an empty bearer.
+func isJwtTokenValid(client *turso.Client, token string) bool {
 	if len(token) == 0 {
 		return false
 	}
-	resp, err := createTursoClient().Get("/v2/validate/token", nil)
+	resp, err := client.Get("/v2/validate/token", nil)
 	return err == nil && resp.StatusCode == http.StatusOK
 }
 
@@ -84,12 +101,23 @@ func login(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("could not retrieve local config: %w", err)
 	}
-	if isJwtTokenValid(settings.GetToken()) {
+	if isLoggedIn() {
 		fmt.Println("✔  Success! Existing JWT still valid")
 		return nil
 	}
+
+	profile := activeProfileName(settings)
+
+	if deviceLogin {
+		return loginDevice(settings, profile)
+	}
+
+	if headlessLogin {
+		return loginHeadless(settings, profile)
+	}
+
 	fmt.Println("Waiting for authentication...")
-	ch := make(chan string, 1)
+	ch := make(chan callbackResult, 1)
 	server, err := createCallbackServer(ch)
 	if err != nil {
 		return fmt.Errorf("internal error. Cannot create callback: %w", err)
@@ -100,10 +128,17 @@ func login(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("internal error. Cannot run authentication server: %w", err)
 	}
 
-	err = beginAuth(port)
+	opened, err := beginAuth(port)
 	if err != nil {
 		return fmt.Errorf("internal error. Cannot initiate auth flow: %w", err)
 	}
+	if !opened && noDisplay() {
+		// No display to open a browser on, and no browser handler available
+		// either (e.g. no xdg-open) — fall back to the device flow instead
+		// of hanging forever waiting on a callback that will never arrive.
+		server.Shutdown(context.Background())
+		return loginDevice(settings, profile)
+	}
 
 	versionChannel := make(chan string, 1)
 
@@ -117,9 +152,9 @@ func login(cmd *cobra.Command, args []string) error {
 		versionChannel <- latestVersion
 	}()
 
-	jwt := <-ch
+	result := <-ch
 
-	err = settings.SetToken(jwt)
+	err = persistLogin(settings, profile, result.jwt, result.refreshToken)
 	server.Shutdown(context.Background())
 
 	if err != nil {
@@ -140,6 +175,50 @@ func login(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// loginHeadless is used over SSH or in other environments without a local
+// browser: instead of spinning up a callback server on a port the remote
+// browser can't reach, it prints the URL for the user to open elsewhere and
+// reads the resulting jwt/refresh_token pair back from stdin.
+func loginHeadless(settings *settings.Settings, profile string) error {
+	// No port to redirect to here, so ask the login page to display the
+	// jwt/refresh_token pair for manual copy-paste instead of redirecting
+	// to a local callback server.
+	authUrl, err := buildAuthUrl(0, false)
+	if err != nil {
+		return fmt.Errorf("internal error. Cannot initiate auth flow: %w", err)
+	}
+
+	fmt.Printf("Open the following URL in a browser and log in, then paste the callback values below:\n%s\n\n", turso.Emph(authUrl.String()))
+
+	reader := bufio.NewReader(os.Stdin)
+
+	fmt.Print("jwt: ")
+	jwt, err := reader.ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("could not read jwt from stdin: %w", err)
+	}
+
+	fmt.Print("refresh_token: ")
+	refreshToken, err := reader.ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("could not read refresh_token from stdin: %w", err)
+	}
+
+	if err := persistLogin(settings, profile, strings.TrimSpace(jwt), strings.TrimSpace(refreshToken)); err != nil {
+		return fmt.Errorf("error persisting token on local config: %w", err)
+	}
+
+	fmt.Println("✔  Success!")
+	return nil
+}
+
+func persistLogin(settings *settings.Settings, profile, jwt, refreshToken string) error {
+	if err := settings.SetToken(profile, jwt); err != nil {
+		return err
+	}
+	return settings.SetRefreshToken(profile, refreshToken)
+}
+
 func fetchLatestVersion() (string, error) {
 	resp, err := createUnauthenticatedTursoClient().Get("/releases/latest", nil)
 	if err != nil {
@@ -165,25 +244,57 @@ func fetchLatestVersion() (string, error) {
 	return versionResp.Version, nil
 }
 
-func beginAuth(port int) error {
+// buildAuthUrl builds the login page URL. When redirect is true, the login
+// page redirects back to a callback server on port once the user
+// authenticates; when false, it displays the jwt/refresh_token pair on the
+// page instead, for callers (like loginHeadless) with no local port to
+// redirect to.
+func buildAuthUrl(port int, redirect bool) (*url.URL, error) {
 	authUrl, err := url.Parse(getHost())
 	if err != nil {
-		return fmt.Errorf("error parsing auth URL: %w", err)
+		return nil, fmt.Errorf("error parsing auth URL: %w", err)
 	}
-	authUrl.RawQuery = url.Values{
-		"port":     {strconv.Itoa(port)},
-		"redirect": {"true"},
-	}.Encode()
+	query := url.Values{}
+	if redirect {
+		query.Set("port", strconv.Itoa(port))
+		query.Set("redirect", "true")
+	}
+	authUrl.RawQuery = query.Encode()
+	return authUrl, nil
+}
 
-	err = browser.OpenURL(authUrl.String())
+// beginAuth opens the login URL in a browser and reports whether it
+// actually managed to do so, so callers without a usable browser can fall
+// back to a flow that doesn't depend on one.
+func beginAuth(port int) (bool, error) {
+	authUrl, err := buildAuthUrl(port, true)
 	if err != nil {
+		return false, err
+	}
+
+	if err := browser.OpenURL(authUrl.String()); err != nil {
 		fmt.Printf("Please open the following URL to login: %s\n", turso.Emph(authUrl.String()))
+		return false, nil
 	}
 
-	return nil
+	return true, nil
+}
+
+// noDisplay reports whether this process has no apparent way to open a
+// local browser window, which is our signal to offer the device flow
+// instead of waiting on a callback that will never arrive.
+func noDisplay() bool {
+	return len(os.Getenv("DISPLAY")) == 0 && len(os.Getenv("WAYLAND_DISPLAY")) == 0
+}
+
+// callbackResult is what the local login page posts back to us once the
+// user has authenticated in the browser.
+type callbackResult struct {
+	jwt          string
+	refreshToken string
 }
 
-func createCallbackServer(jwtCh chan string) (*http.Server, error) {
+func createCallbackServer(resultCh chan callbackResult) (*http.Server, error) {
 	tmpl, err := template.New("login.html").Parse(LOGIN_HTML)
 	if err != nil {
 		return nil, fmt.Errorf("could not parse login callback template: %w", err)
@@ -192,7 +303,7 @@ func createCallbackServer(jwtCh chan string) (*http.Server, error) {
 	handler := http.NewServeMux()
 	handler.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		q := r.URL.Query()
-		jwtCh <- q.Get("jwt")
+		resultCh <- callbackResult{jwt: q.Get("jwt"), refreshToken: q.Get("refresh_token")}
 
 		w.WriteHeader(200)
 		tmpl.Execute(w, q.Get("username"))
@@ -221,11 +332,24 @@ func logout(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("could not retrieve local config: %w", err)
 	}
 
-	token := settings.GetToken()
+	if len(logoutRevokeApiToken) > 0 {
+		client, err := createTursoClientFromAccessToken(true)
+		if err != nil {
+			return err
+		}
+		if err := client.ApiTokens.Revoke(logoutRevokeApiToken); err != nil {
+			return fmt.Errorf("could not revoke API token %s: %w", logoutRevokeApiToken, err)
+		}
+		fmt.Printf("Revoked API token %s.\n", turso.Emph(logoutRevokeApiToken))
+	}
+
+	profile := activeProfileName(settings)
+	token := settings.GetToken(profile)
 	if len(token) == 0 {
 		fmt.Println("No user logged in.")
 	} else {
-		settings.SetToken("")
+		settings.SetToken(profile, "")
+		settings.SetRefreshToken(profile, "")
 		fmt.Println("Logged out.")
 	}
 
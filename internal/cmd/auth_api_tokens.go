@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/chiselstrike/iku-turso-cli/internal/turso"
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+)
+
+var apiTokensCmd = &cobra.Command{
+	Use:               "api-tokens",
+	Short:             "Manage your API tokens.",
+	ValidArgsFunction: noSpaceArg,
+}
+
+var apiTokensMintCmd = &cobra.Command{
+	Use:               "mint <token-name>",
+	Short:             "Mint an API token.",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: noFilesArg,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		client, err := createTursoClientFromAccessToken(true)
+		if err != nil {
+			return err
+		}
+
+		token, err := client.ApiTokens.Mint(args[0])
+		if err != nil {
+			return fmt.Errorf("could not mint API token: %w", err)
+		}
+
+		fmt.Println(token)
+		fmt.Println()
+		fmt.Println("Store it somewhere safe, since this is the only time it will be shown.")
+		return nil
+	},
+}
+
+var apiTokensListCmd = &cobra.Command{
+	Use:               "list",
+	Short:             "List your API tokens.",
+	Args:              cobra.NoArgs,
+	ValidArgsFunction: noFilesArg,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		client, err := createTursoClientFromAccessToken(true)
+		if err != nil {
+			return err
+		}
+
+		tokens, err := client.ApiTokens.List()
+		if err != nil {
+			return fmt.Errorf("could not list API tokens: %w", err)
+		}
+
+		if len(tokens) == 0 {
+			fmt.Println("You have no API tokens yet. Mint one with `turso auth api-tokens mint <name>`.")
+			return nil
+		}
+
+		tbl := table.New("NAME", "ID")
+		for _, token := range tokens {
+			tbl.AddRow(token.Name, token.Id)
+		}
+		tbl.Print()
+		return nil
+	},
+}
+
+var apiTokensRevokeCmd = &cobra.Command{
+	Use:               "revoke <token-name>",
+	Short:             "Revoke an API token.",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: noFilesArg,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		client, err := createTursoClientFromAccessToken(true)
+		if err != nil {
+			return err
+		}
+
+		name := args[0]
+		if err := client.ApiTokens.Revoke(name); err != nil {
+			return fmt.Errorf("could not revoke API token %s: %w", name, err)
+		}
+
+		fmt.Printf("Revoked API token %s.\n", turso.Emph(name))
+		return nil
+	},
+}
+
+func init() {
+	authCmd.AddCommand(apiTokensCmd)
+	apiTokensCmd.AddCommand(apiTokensMintCmd)
+	apiTokensCmd.AddCommand(apiTokensListCmd)
+	apiTokensCmd.AddCommand(apiTokensRevokeCmd)
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/chiselstrike/iku-turso-cli/internal/settings"
+	"github.com/chiselstrike/iku-turso-cli/internal/turso"
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+const defaultDevicePollInterval = 5 * time.Second
+
+type deviceCode struct {
+	DeviceCode      string `json:"device_code"`
+	UserCode        string `json:"user_code"`
+	VerificationUri string `json:"verification_uri"`
+	Interval        int    `json:"interval"`
+	ExpiresIn       int    `json:"expires_in"`
+}
+
+type deviceTokenResponse struct {
+	Jwt   string `json:"jwt"`
+	Error string `json:"error"`
+}
+
+// loginDevice drives the OAuth device-authorization flow: it has no
+// dependency on a local browser or an open port, so it also works over SSH,
+// in containers, and on displayless servers.
+func loginDevice(s *settings.Settings, profile string) error {
+	client := createUnauthenticatedTursoClient()
+
+	code, err := requestDeviceCode(client)
+	if err != nil {
+		return fmt.Errorf("could not start device login: %w", err)
+	}
+
+	fmt.Printf("First copy your one-time code: %s\n\n", turso.Emph(code.UserCode))
+	fmt.Printf("Then open this URL in a browser on any device to continue:\n%s\n\n", turso.Emph(code.VerificationUri))
+
+	if qr, err := qrcode.New(code.VerificationUri, qrcode.Medium); err == nil {
+		fmt.Println(qr.ToString(false))
+	}
+
+	fmt.Println("Waiting for confirmation...")
+
+	jwt, err := pollDeviceToken(client, code)
+	if err != nil {
+		return err
+	}
+
+	if err := s.SetToken(profile, jwt); err != nil {
+		return fmt.Errorf("error persisting token on local config: %w", err)
+	}
+
+	fmt.Println("✔  Success!")
+	return nil
+}
+
+func requestDeviceCode(client *turso.Client) (*deviceCode, error) {
+	resp, err := client.Post("/v2/auth/device/code", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error requesting device code: %s", resp.Status)
+	}
+
+	var code deviceCode
+	if err := json.NewDecoder(resp.Body).Decode(&code); err != nil {
+		return nil, fmt.Errorf("could not parse device code response: %w", err)
+	}
+	return &code, nil
+}
+
+// pollDeviceToken repeatedly asks /v2/auth/device/token whether the user has
+// finished authorizing the device code, honoring the server's requested
+// interval and backing off whenever it asks us to slow down.
+func pollDeviceToken(client *turso.Client, code *deviceCode) (string, error) {
+	interval := time.Duration(code.Interval) * time.Second
+	if interval <= 0 {
+		interval = defaultDevicePollInterval
+	}
+	deadline := time.Now().Add(time.Duration(code.ExpiresIn) * time.Second)
+
+	body, err := json.Marshal(struct {
+		DeviceCode string `json:"device_code"`
+	}{DeviceCode: code.DeviceCode})
+	if err != nil {
+		return "", fmt.Errorf("could not serialize device token request: %w", err)
+	}
+
+	for {
+		if time.Now().After(deadline) {
+			return "", fmt.Errorf("device login expired before it was confirmed. Run `turso auth login --device` again")
+		}
+		time.Sleep(interval)
+
+		resp, err := client.Post("/v2/auth/device/token", body)
+		if err != nil {
+			return "", err
+		}
+		var parsed deviceTokenResponse
+		decodeErr := json.NewDecoder(resp.Body).Decode(&parsed)
+		statusCode := resp.StatusCode
+		resp.Body.Close()
+		if decodeErr != nil {
+			return "", fmt.Errorf("could not parse device token response: %w", decodeErr)
+		}
+
+		step := nextDevicePollStep(statusCode, parsed, interval)
+		if step.done {
+			return step.jwt, step.err
+		}
+		interval = step.interval
+	}
+}
+
+// devicePollStep is what pollDeviceToken should do after one response: keep
+// polling at interval, or stop with a jwt or an error.
+type devicePollStep struct {
+	jwt      string
+	done     bool
+	err      error
+	interval time.Duration
+}
+
+// nextDevicePollStep interprets one /v2/auth/device/token response. It's
+// factored out of pollDeviceToken as pure logic so the slow_down backoff and
+// authorization_pending/failure handling can be tested without real timers
+// or network.
+func nextDevicePollStep(statusCode int, parsed deviceTokenResponse, interval time.Duration) devicePollStep {
+	switch {
+	case statusCode == http.StatusOK && len(parsed.Jwt) > 0:
+		return devicePollStep{jwt: parsed.Jwt, done: true}
+	case parsed.Error == "slow_down":
+		return devicePollStep{interval: interval * 2}
+	case parsed.Error == "authorization_pending":
+		return devicePollStep{interval: interval}
+	default:
+		return devicePollStep{done: true, err: fmt.Errorf("device login failed: %s", parsed.Error)}
+	}
+}
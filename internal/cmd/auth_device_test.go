@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestNextDevicePollStep_Success(t *testing.T) {
+	step := nextDevicePollStep(http.StatusOK, deviceTokenResponse{Jwt: "a.b.c"}, time.Second)
+	if !step.done || step.err != nil || step.jwt != "a.b.c" {
+		t.Errorf("got %+v, want done with jwt and no error", step)
+	}
+}
+
+func TestNextDevicePollStep_SlowDownDoublesInterval(t *testing.T) {
+	step := nextDevicePollStep(http.StatusForbidden, deviceTokenResponse{Error: "slow_down"}, 5*time.Second)
+	if step.done {
+		t.Fatalf("got done=true, want polling to continue")
+	}
+	if step.interval != 10*time.Second {
+		t.Errorf("got interval %v, want doubled to 10s", step.interval)
+	}
+
+	// Backoff compounds across repeated slow_down responses.
+	step = nextDevicePollStep(http.StatusForbidden, deviceTokenResponse{Error: "slow_down"}, step.interval)
+	if step.interval != 20*time.Second {
+		t.Errorf("got interval %v, want doubled again to 20s", step.interval)
+	}
+}
+
+func TestNextDevicePollStep_AuthorizationPendingKeepsInterval(t *testing.T) {
+	step := nextDevicePollStep(http.StatusForbidden, deviceTokenResponse{Error: "authorization_pending"}, 5*time.Second)
+	if step.done {
+		t.Errorf("got done=true, want polling to continue")
+	}
+	if step.interval != 5*time.Second {
+		t.Errorf("got interval %v, want unchanged at 5s", step.interval)
+	}
+}
+
+func TestNextDevicePollStep_OtherErrorFails(t *testing.T) {
+	step := nextDevicePollStep(http.StatusForbidden, deviceTokenResponse{Error: "access_denied"}, time.Second)
+	if !step.done || step.err == nil {
+		t.Errorf("got %+v, want done with an error", step)
+	}
+}
+
+func TestPollDeviceToken_ExpiresBeforeFirstPoll(t *testing.T) {
+	// ExpiresIn: 0 means the deadline is effectively now, so pollDeviceToken
+	// must report expiry without ever dialing out.
+	code := &deviceCode{DeviceCode: "dc", Interval: 1, ExpiresIn: 0}
+	if _, err := pollDeviceToken(nil, code); err == nil {
+		t.Error("expected an expiry error before any request was attempted")
+	}
+}
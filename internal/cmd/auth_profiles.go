@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/chiselstrike/iku-turso-cli/internal/settings"
+	"github.com/chiselstrike/iku-turso-cli/internal/turso"
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+)
+
+var profilesCmd = &cobra.Command{
+	Use:               "profiles",
+	Short:             "Manage named auth profiles.",
+	ValidArgsFunction: noSpaceArg,
+}
+
+var profilesListCmd = &cobra.Command{
+	Use:               "list",
+	Short:             "List configured profiles.",
+	Args:              cobra.NoArgs,
+	ValidArgsFunction: noFilesArg,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		s, err := settings.ReadSettings()
+		if err != nil {
+			return fmt.Errorf("could not retrieve local config: %w", err)
+		}
+
+		current := activeProfileName(s)
+		tbl := table.New("", "NAME", "DEFAULT ORG")
+		for _, name := range s.ListProfileNames() {
+			marker := ""
+			if name == current {
+				marker = "*"
+			}
+			tbl.AddRow(marker, name, s.GetDefaultOrg(name))
+		}
+		tbl.Print()
+		return nil
+	},
+}
+
+var profilesUseCmd = &cobra.Command{
+	Use:               "use <profile>",
+	Short:             "Switch the active profile.",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: noFilesArg,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		s, err := settings.ReadSettings()
+		if err != nil {
+			return fmt.Errorf("could not retrieve local config: %w", err)
+		}
+		if err := s.UseProfile(args[0]); err != nil {
+			return err
+		}
+		fmt.Printf("Now using profile %s.\n", turso.Emph(args[0]))
+		return nil
+	},
+}
+
+var addProfileHost string
+var addProfileDefaultOrg string
+
+var profilesAddCmd = &cobra.Command{
+	Use:               "add <profile>",
+	Short:             "Create a new, empty profile.",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: noFilesArg,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		s, err := settings.ReadSettings()
+		if err != nil {
+			return fmt.Errorf("could not retrieve local config: %w", err)
+		}
+		if err := s.AddProfile(args[0], addProfileHost, addProfileDefaultOrg); err != nil {
+			return err
+		}
+		fmt.Printf("Created profile %s. Run `turso auth login --profile %s` to log in to it.\n", turso.Emph(args[0]), args[0])
+		return nil
+	},
+}
+
+var profilesRemoveCmd = &cobra.Command{
+	Use:               "remove <profile>",
+	Short:             "Remove a profile.",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: noFilesArg,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		s, err := settings.ReadSettings()
+		if err != nil {
+			return fmt.Errorf("could not retrieve local config: %w", err)
+		}
+		if err := s.RemoveProfile(args[0]); err != nil {
+			return err
+		}
+		fmt.Printf("Removed profile %s.\n", turso.Emph(args[0]))
+		return nil
+	},
+}
+
+var profilesRenameCmd = &cobra.Command{
+	Use:               "rename <profile> <new-name>",
+	Short:             "Rename a profile.",
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: noFilesArg,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		s, err := settings.ReadSettings()
+		if err != nil {
+			return fmt.Errorf("could not retrieve local config: %w", err)
+		}
+		if err := s.RenameProfile(args[0], args[1]); err != nil {
+			return err
+		}
+		fmt.Printf("Renamed profile %s to %s.\n", turso.Emph(args[0]), turso.Emph(args[1]))
+		return nil
+	},
+}
+
+func init() {
+	profilesAddCmd.Flags().StringVar(&addProfileHost, "host", "", "Override the API host for this profile.")
+	profilesAddCmd.Flags().StringVar(&addProfileDefaultOrg, "default-org", "", "Default organization for this profile.")
+
+	authCmd.AddCommand(profilesCmd)
+	profilesCmd.AddCommand(profilesListCmd)
+	profilesCmd.AddCommand(profilesUseCmd)
+	profilesCmd.AddCommand(profilesAddCmd)
+	profilesCmd.AddCommand(profilesRemoveCmd)
+	profilesCmd.AddCommand(profilesRenameCmd)
+}
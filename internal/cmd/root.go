@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+
+	"github.com/chiselstrike/iku-turso-cli/internal/settings"
+	"github.com/chiselstrike/iku-turso-cli/internal/turso"
+	"github.com/spf13/cobra"
+)
+
+// version is overridden at build time with -ldflags.
+var version = "dev"
+
+var rootCmd = &cobra.Command{
+	Use:   "turso",
+	Short: "Turso CLI",
+}
+
+// apiTokenFlag is the global --api-token override, for CI and other
+// non-interactive callers that would rather pass a minted API token than
+// log in. TURSO_API_TOKEN takes the same role and is checked first.
+var apiTokenFlag string
+
+// profileFlag is the global --profile override, for one-shot use of a
+// profile other than the active one. TURSO_PROFILE takes the same role.
+var profileFlag string
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&apiTokenFlag, "api-token", "", "Use this API token instead of the logged-in session. Can also be set with TURSO_API_TOKEN.")
+	rootCmd.PersistentFlags().StringVar(&profileFlag, "profile", "", "Use this profile instead of the active one. Can also be set with TURSO_PROFILE.")
+}
+
+// activeProfileName resolves which profile a command should act on: the
+// --profile flag, then TURSO_PROFILE, then whatever `turso auth profiles
+// use` last selected.
+func activeProfileName(s *settings.Settings) string {
+	if len(profileFlag) > 0 {
+		return profileFlag
+	}
+	if profile := os.Getenv("TURSO_PROFILE"); len(profile) > 0 {
+		return profile
+	}
+	return s.CurrentProfileName()
+}
+
+// Execute runs the root command. It's the sole entry point called from main.
+func Execute() error {
+	return rootCmd.Execute()
+}
+
+func noSpaceArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return nil, cobra.ShellCompDirectiveNoSpace
+}
+
+func noFilesArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
+// getHost returns the base URL of the web app used for the browser login
+// flow, overridable for local development and staging.
+func getHost() string {
+	if host := os.Getenv("TURSO_WEB_HOST"); len(host) > 0 {
+		return host
+	}
+	return "https://turso.tech"
+}
+
+// getApiUrl returns the base URL of the platform API.
+func getApiUrl() (*url.URL, error) {
+	host := "https://api.turso.tech"
+	if h := os.Getenv("TURSO_API_HOST"); len(h) > 0 {
+		host = h
+	}
+	u, err := url.Parse(host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid API host %q: %w", host, err)
+	}
+	return u, nil
+}
+
+// isLoggedIn reports whether the active profile is ready to make
+// authenticated requests, skipping server validation for credentials whose
+// RequiresValidation() is false — mirroring
+// createTursoClientFromAccessTokenForProfile.
+func isLoggedIn() bool {
+	s, err := settings.ReadSettings()
+	if err != nil {
+		return false
+	}
+	profile := activeProfileName(s)
+	client, credential, err := newAuthenticatedClientForProfile(s, profile)
+	if err != nil {
+		return false
+	}
+	if !credential.RequiresValidation() {
+		return true
+	}
+	return isJwtTokenValid(client, s.GetToken(profile))
+}
+
+// createTursoClientFromAccessToken builds a client for the active profile,
+// surfacing settings/validation errors instead of swallowing them, for
+// commands that can't proceed without a real token.
+func createTursoClientFromAccessToken(shouldValidate bool) (*turso.Client, error) {
+	s, err := settings.ReadSettings()
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve local config: %w", err)
+	}
+	return createTursoClientFromAccessTokenForProfile(s, activeProfileName(s), shouldValidate)
+}
+
+// createTursoClientFromAccessTokenForProfile is like
+// createTursoClientFromAccessToken, but for an explicitly named profile
+// rather than the active one. It's what lets `account show --all` build one
+// client per profile and fan out across them concurrently.
+func createTursoClientFromAccessTokenForProfile(s *settings.Settings, profile string, shouldValidate bool) (*turso.Client, error) {
+	client, credential, err := newAuthenticatedClientForProfile(s, profile)
+	if err != nil {
+		return nil, err
+	}
+	if shouldValidate && credential.RequiresValidation() && !isJwtTokenValid(client, s.GetToken(profile)) {
+		return nil, fmt.Errorf("no user logged in for profile %q. Run `turso auth login --profile %s` to log in and get a token", profile, profile)
+	}
+	return client, nil
+}
+
+func createUnauthenticatedTursoClient() *turso.Client {
+	apiUrl, _ := getApiUrl()
+	return turso.New(apiUrl, nil)
+}
+
+// newAuthenticatedClient resolves credentials for the active profile, in
+// priority order: the TURSO_API_TOKEN env var, the --api-token flag, and
+// finally the JWT obtained through `turso auth login`.
+func newAuthenticatedClient() (*turso.Client, turso.Credential, error) {
+	s, err := settings.ReadSettings()
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not retrieve local config: %w", err)
+	}
+	return newAuthenticatedClientForProfile(s, activeProfileName(s))
+}
+
+func newAuthenticatedClientForProfile(s *settings.Settings, profile string) (*turso.Client, turso.Credential, error) {
+	apiUrl, err := resolveApiUrl(s, profile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	credential := resolveCredential(s, profile, apiUrl)
+	return turso.New(apiUrl, credential), credential, nil
+}
+
+// resolveApiUrl lets a profile point at a different host than the default
+// platform API, e.g. for a self-hosted instance.
+func resolveApiUrl(s *settings.Settings, profile string) (*url.URL, error) {
+	host := s.GetHost(profile)
+	if len(host) == 0 {
+		return getApiUrl()
+	}
+	u, err := url.Parse(host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid API host %q for profile %q: %w", host, profile, err)
+	}
+	return u, nil
+}
+
+func resolveCredential(s *settings.Settings, profile string, apiUrl *url.URL) turso.Credential {
+	if token := os.Getenv("TURSO_API_TOKEN"); len(token) > 0 {
+		return turso.NewApiKeyCredential(token)
+	}
+	if len(apiTokenFlag) > 0 {
+		return turso.NewApiKeyCredential(apiTokenFlag)
+	}
+
+	refreshClient := turso.New(apiUrl, nil)
+	store := settings.NewProfileStore(s, profile)
+	return turso.NewJwtCredential(refreshClient, store, s.GetToken(profile), s.GetRefreshToken(profile))
+}
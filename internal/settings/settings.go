@@ -0,0 +1,287 @@
+package settings
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+const settingsFileName = "settings.json"
+
+// DefaultProfileName is used whenever the user has never created or
+// switched profiles, so existing single-account setups keep working
+// exactly as they did before profiles existed.
+const DefaultProfileName = "default"
+
+// Settings is the local, on-disk configuration for the CLI: one or more
+// named auth profiles plus a pointer to the active one. It is safe for
+// concurrent use.
+type Settings struct {
+	mu   sync.Mutex
+	path string
+	data settingsData
+}
+
+type settingsData struct {
+	CurrentProfile string                  `json:"current_profile,omitempty"`
+	Profiles       map[string]*profileData `json:"profiles,omitempty"`
+}
+
+type profileData struct {
+	Token        string `json:"token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	Host         string `json:"host,omitempty"`
+	DefaultOrg   string `json:"default_org,omitempty"`
+}
+
+var (
+	once     sync.Once
+	instance *Settings
+	initErr  error
+)
+
+// ReadSettings loads the settings file from disk, creating its directory on
+// first use; the file itself is first written when a setting changes.
+// Subsequent calls return the same, already-loaded instance.
+func ReadSettings() (*Settings, error) {
+	once.Do(func() {
+		instance, initErr = load()
+	})
+	return instance, initErr
+}
+
+func configHome() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("could not determine home directory: %w", err)
+	}
+	return filepath.Join(home, ".turso"), nil
+}
+
+func load() (*Settings, error) {
+	dir, err := configHome()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("could not create settings directory: %w", err)
+	}
+
+	path := filepath.Join(dir, settingsFileName)
+	s := &Settings{path: path}
+
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("could not read settings file: %w", err)
+	}
+	if len(bytes) == 0 {
+		return s, nil
+	}
+	if err := json.Unmarshal(bytes, &s.data); err != nil {
+		return nil, fmt.Errorf("could not parse settings file: %w", err)
+	}
+	return s, nil
+}
+
+// persist atomically rewrites the settings file so a crash or a concurrent
+// command never observes a half-written file.
+func (s *Settings) persist() error {
+	bytes, err := json.MarshalIndent(s.data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not serialize settings: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), settingsFileName+".*")
+	if err != nil {
+		return fmt.Errorf("could not create temporary settings file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(bytes); err != nil {
+		tmp.Close()
+		return fmt.Errorf("could not write settings file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("could not write settings file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("could not persist settings file: %w", err)
+	}
+	return nil
+}
+
+// profile returns a copy of the named profile's data, or a zero value if it
+// has never been written to. Callers must hold s.mu.
+func (s *Settings) profile(name string) profileData {
+	if p, ok := s.data.Profiles[name]; ok {
+		return *p
+	}
+	return profileData{}
+}
+
+// mutateProfile applies fn to the named profile, creating it first if
+// necessary, and persists the result. Callers must hold s.mu.
+func (s *Settings) mutateProfile(name string, fn func(*profileData)) error {
+	if s.data.Profiles == nil {
+		s.data.Profiles = map[string]*profileData{}
+	}
+	p, ok := s.data.Profiles[name]
+	if !ok {
+		p = &profileData{}
+		s.data.Profiles[name] = p
+	}
+	fn(p)
+	return s.persist()
+}
+
+// CurrentProfileName is the profile commands use absent an explicit
+// --profile flag or TURSO_PROFILE override.
+func (s *Settings) CurrentProfileName() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.data.CurrentProfile) > 0 {
+		return s.data.CurrentProfile
+	}
+	return DefaultProfileName
+}
+
+// UseProfile makes name the default profile for future commands. name must
+// already exist, except for DefaultProfileName which always implicitly does.
+func (s *Settings) UseProfile(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.data.Profiles[name]; !ok && name != DefaultProfileName {
+		return fmt.Errorf("profile %q does not exist. Run `turso auth profiles add %s` first", name, name)
+	}
+	s.data.CurrentProfile = name
+	return s.persist()
+}
+
+// AddProfile creates a new, empty profile. host and defaultOrg may be empty.
+func (s *Settings) AddProfile(name, host, defaultOrg string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.data.Profiles[name]; ok {
+		return fmt.Errorf("profile %q already exists", name)
+	}
+	return s.mutateProfile(name, func(p *profileData) {
+		p.Host = host
+		p.DefaultOrg = defaultOrg
+	})
+}
+
+// RemoveProfile deletes a profile. If it was the active profile, future
+// commands fall back to DefaultProfileName until another is chosen.
+func (s *Settings) RemoveProfile(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.data.Profiles[name]; !ok {
+		return fmt.Errorf("profile %q does not exist", name)
+	}
+	delete(s.data.Profiles, name)
+	if s.data.CurrentProfile == name {
+		s.data.CurrentProfile = ""
+	}
+	return s.persist()
+}
+
+// RenameProfile renames a profile in place, carrying over its current
+// profile status if it had any.
+func (s *Settings) RenameProfile(oldName, newName string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.data.Profiles[oldName]
+	if !ok {
+		return fmt.Errorf("profile %q does not exist", oldName)
+	}
+	if _, exists := s.data.Profiles[newName]; exists {
+		return fmt.Errorf("profile %q already exists", newName)
+	}
+
+	delete(s.data.Profiles, oldName)
+	s.data.Profiles[newName] = p
+	if s.data.CurrentProfile == oldName {
+		s.data.CurrentProfile = newName
+	}
+	return s.persist()
+}
+
+// ListProfileNames returns every profile that has been created, sorted by
+// name. If none have, it reports DefaultProfileName, since that's the
+// profile every command falls back to.
+func (s *Settings) ListProfileNames() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.data.Profiles) == 0 {
+		return []string{DefaultProfileName}
+	}
+	names := make([]string, 0, len(s.data.Profiles))
+	for name := range s.data.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (s *Settings) GetToken(profile string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.profile(profile).Token
+}
+
+func (s *Settings) SetToken(profile, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mutateProfile(profile, func(p *profileData) { p.Token = token })
+}
+
+func (s *Settings) GetRefreshToken(profile string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.profile(profile).RefreshToken
+}
+
+func (s *Settings) SetRefreshToken(profile, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mutateProfile(profile, func(p *profileData) { p.RefreshToken = token })
+}
+
+func (s *Settings) GetHost(profile string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.profile(profile).Host
+}
+
+func (s *Settings) GetDefaultOrg(profile string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.profile(profile).DefaultOrg
+}
+
+// ProfileStore adapts a single profile of Settings to the narrow persister
+// interface internal/turso needs in order to save a refreshed token,
+// without internal/turso knowing profiles exist.
+type ProfileStore struct {
+	settings *Settings
+	profile  string
+}
+
+func NewProfileStore(s *Settings, profile string) *ProfileStore {
+	return &ProfileStore{settings: s, profile: profile}
+}
+
+func (p *ProfileStore) SetToken(token string) error {
+	return p.settings.SetToken(p.profile, token)
+}
+
+func (p *ProfileStore) SetRefreshToken(token string) error {
+	return p.settings.SetRefreshToken(p.profile, token)
+}
@@ -0,0 +1,96 @@
+package settings
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func newTestSettings(t *testing.T) *Settings {
+	t.Helper()
+	return &Settings{path: filepath.Join(t.TempDir(), settingsFileName)}
+}
+
+func TestListProfileNames_DefaultsWhenEmpty(t *testing.T) {
+	s := newTestSettings(t)
+	names := s.ListProfileNames()
+	if len(names) != 1 || names[0] != DefaultProfileName {
+		t.Errorf("got %v, want [%s]", names, DefaultProfileName)
+	}
+}
+
+func TestRenameProfile_CarriesOverCurrentProfile(t *testing.T) {
+	s := newTestSettings(t)
+	if err := s.AddProfile("work", "", ""); err != nil {
+		t.Fatalf("AddProfile: %v", err)
+	}
+	if err := s.UseProfile("work"); err != nil {
+		t.Fatalf("UseProfile: %v", err)
+	}
+
+	if err := s.RenameProfile("work", "job"); err != nil {
+		t.Fatalf("RenameProfile: %v", err)
+	}
+
+	if got := s.CurrentProfileName(); got != "job" {
+		t.Errorf("CurrentProfileName() = %q, want %q", got, "job")
+	}
+	names := s.ListProfileNames()
+	if len(names) != 1 || names[0] != "job" {
+		t.Errorf("got %v, want [job]", names)
+	}
+}
+
+func TestRenameProfile_DoesNotExist(t *testing.T) {
+	s := newTestSettings(t)
+	if err := s.RenameProfile("ghost", "new"); err == nil {
+		t.Error("expected an error renaming a profile that doesn't exist")
+	}
+}
+
+func TestRenameProfile_TargetAlreadyExists(t *testing.T) {
+	s := newTestSettings(t)
+	if err := s.AddProfile("a", "", ""); err != nil {
+		t.Fatalf("AddProfile: %v", err)
+	}
+	if err := s.AddProfile("b", "", ""); err != nil {
+		t.Fatalf("AddProfile: %v", err)
+	}
+	if err := s.RenameProfile("a", "b"); err == nil {
+		t.Error("expected an error renaming onto an existing profile name")
+	}
+}
+
+func TestRemoveProfile_CurrentFallsBackToDefault(t *testing.T) {
+	s := newTestSettings(t)
+	if err := s.AddProfile("work", "", ""); err != nil {
+		t.Fatalf("AddProfile: %v", err)
+	}
+	if err := s.UseProfile("work"); err != nil {
+		t.Fatalf("UseProfile: %v", err)
+	}
+
+	if err := s.RemoveProfile("work"); err != nil {
+		t.Fatalf("RemoveProfile: %v", err)
+	}
+
+	if got := s.CurrentProfileName(); got != DefaultProfileName {
+		t.Errorf("CurrentProfileName() = %q, want fallback to %q", got, DefaultProfileName)
+	}
+}
+
+func TestRemoveProfile_DoesNotExist(t *testing.T) {
+	s := newTestSettings(t)
+	if err := s.RemoveProfile("ghost"); err == nil {
+		t.Error("expected an error removing a profile that doesn't exist")
+	}
+}
+
+func TestUseProfile_MustExistUnlessDefault(t *testing.T) {
+	s := newTestSettings(t)
+	if err := s.UseProfile("ghost"); err == nil {
+		t.Error("expected an error switching to a profile that was never added")
+	}
+	if err := s.UseProfile(DefaultProfileName); err != nil {
+		t.Errorf("UseProfile(%q) should always succeed, got: %v", DefaultProfileName, err)
+	}
+}
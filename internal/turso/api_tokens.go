@@ -0,0 +1,74 @@
+package turso
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// ApiTokensClient manages long-lived, revocable API tokens through
+// /v2/api-tokens, for CI jobs, cron, and other non-interactive callers that
+// can't go through the browser login flow.
+type ApiTokensClient struct {
+	client *Client
+}
+
+type ApiToken struct {
+	Name string `json:"name"`
+	Id   string `json:"id"`
+}
+
+// Mint creates a new API token with the given name and returns the token
+// value. The server only ever returns the value once.
+func (c *ApiTokensClient) Mint(name string) (string, error) {
+	resp, err := c.client.Post(fmt.Sprintf("/v2/api-tokens/%s", name), nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("could not mint API token: %s", resp.Status)
+	}
+
+	var parsed struct {
+		Token string `json:"token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return "", fmt.Errorf("could not parse mint response: %w", err)
+	}
+	return parsed.Token, nil
+}
+
+// List returns the API tokens minted for the current account. It never
+// returns token values, only their names and ids.
+func (c *ApiTokensClient) List() ([]ApiToken, error) {
+	resp, err := c.client.Get("/v2/api-tokens", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not list API tokens: %s", resp.Status)
+	}
+
+	var parsed struct {
+		Tokens []ApiToken `json:"tokens"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("could not parse list response: %w", err)
+	}
+	return parsed.Tokens, nil
+}
+
+// Revoke invalidates the named API token server-side.
+func (c *ApiTokensClient) Revoke(name string) error {
+	resp, err := c.client.Delete(fmt.Sprintf("/v2/api-tokens/%s", name), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not revoke API token: %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package turso
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/fatih/color"
+)
+
+// Client is a thin wrapper around the Turso platform API. Every request it
+// sends is authenticated through Token, which is given the chance to
+// refresh itself before each call.
+type Client struct {
+	baseUrl *url.URL
+	http    *http.Client
+	Token   TokenSource
+
+	ApiTokens *ApiTokensClient
+}
+
+// New creates a Client for baseUrl. Token may be nil for endpoints that
+// don't require authentication, such as the release-version check.
+func New(baseUrl *url.URL, token TokenSource) *Client {
+	c := &Client{baseUrl: baseUrl, http: http.DefaultClient, Token: token}
+	c.ApiTokens = &ApiTokensClient{client: c}
+	return c
+}
+
+func (c *Client) newRequest(method, path string, body []byte) (*http.Request, error) {
+	reqUrl := *c.baseUrl
+	reqUrl.Path = path
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequest(method, reqUrl.String(), reader)
+	if err != nil {
+		return nil, fmt.Errorf("could not create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return req, nil
+}
+
+// do sends req, authenticating it with the current token and retrying
+// exactly once if the server comes back with 401 — which covers the case
+// where the token expired between our local check and the server's. The
+// retry force-refreshes the token (Token() alone would see the same
+// locally-valid token and send it again) and rebuilds req, since its body
+// was already consumed by the first Do.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	if c.Token == nil {
+		return c.http.Do(req)
+	}
+
+	token, err := c.Token.Token()
+	if err != nil {
+		return nil, fmt.Errorf("could not get auth token: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusUnauthorized {
+		return resp, nil
+	}
+
+	refresher, ok := c.Token.(forceRefreshingTokenSource)
+	if !ok {
+		return resp, nil
+	}
+	resp.Body.Close()
+
+	token, err = refresher.ForceRefresh()
+	if err != nil {
+		return nil, fmt.Errorf("could not refresh auth token: %w", err)
+	}
+
+	retry, err := cloneRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not rebuild request for retry: %w", err)
+	}
+	retry.Header.Set("Authorization", "Bearer "+token.AccessToken)
+
+	return c.http.Do(retry)
+}
+
+// cloneRequest rebuilds req so it can be safely re-sent. The first Do above
+// already consumed (and the caller will have closed) req.Body, so reusing
+// req as-is would send an empty body on a retried POST/DELETE.
+func cloneRequest(req *http.Request) (*http.Request, error) {
+	clone := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("could not re-read request body: %w", err)
+		}
+		clone.Body = body
+	}
+	return clone, nil
+}
+
+func (c *Client) Get(path string, body []byte) (*http.Response, error) {
+	req, err := c.newRequest(http.MethodGet, path, body)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(req)
+}
+
+func (c *Client) Post(path string, body []byte) (*http.Response, error) {
+	req, err := c.newRequest(http.MethodPost, path, body)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(req)
+}
+
+func (c *Client) Delete(path string, body []byte) (*http.Response, error) {
+	req, err := c.newRequest(http.MethodDelete, path, body)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(req)
+}
+
+// RefreshToken exchanges refreshToken for a new JWT/refresh-token pair at
+// /v2/auth/refresh. It bypasses Token entirely, since refreshing a token is
+// the one request that must not itself try to refresh.
+func (c *Client) RefreshToken(refreshToken string) (token string, newRefreshToken string, err error) {
+	body, err := json.Marshal(struct {
+		RefreshToken string `json:"refresh_token"`
+	}{RefreshToken: refreshToken})
+	if err != nil {
+		return "", "", fmt.Errorf("could not serialize refresh request: %w", err)
+	}
+
+	req, err := c.newRequest(http.MethodPost, "/v2/auth/refresh", body)
+	if err != nil {
+		return "", "", err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return "", "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("refresh request failed: %s", resp.Status)
+	}
+
+	var parsed struct {
+		Jwt          string `json:"jwt"`
+		RefreshToken string `json:"refresh_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return "", "", fmt.Errorf("could not parse refresh response: %w", err)
+	}
+
+	return parsed.Jwt, parsed.RefreshToken, nil
+}
+
+func Emph(str string) string {
+	return color.New(color.Bold).Sprint(str)
+}
@@ -0,0 +1,41 @@
+package turso
+
+// Credential authenticates requests and reports whether it's worth the
+// round trip of confirming its validity with the server before first use.
+// JWTs silently expire underneath us, so they're worth checking; API keys
+// are either accepted or rejected outright on first use, so checking them
+// ahead of time is redundant.
+type Credential interface {
+	TokenSource
+	RequiresValidation() bool
+}
+
+type apiKeyCredential string
+
+// NewApiKeyCredential wraps a long-lived API key as a Credential. It never
+// expires and is never refreshed.
+func NewApiKeyCredential(key string) Credential {
+	return apiKeyCredential(key)
+}
+
+func (k apiKeyCredential) Token() (*Token, error) {
+	return &Token{AccessToken: string(k)}, nil
+}
+
+func (k apiKeyCredential) RequiresValidation() bool {
+	return false
+}
+
+type jwtCredential struct {
+	*jwtTokenSource
+}
+
+// NewJwtCredential wraps a browser-issued JWT/refresh-token pair as a
+// Credential, refreshing it through client as it approaches expiry.
+func NewJwtCredential(client *Client, store persister, token, refreshToken string) Credential {
+	return &jwtCredential{jwtTokenSource: &jwtTokenSource{client: client, store: store, token: token, refreshToken: refreshToken}}
+}
+
+func (c *jwtCredential) RequiresValidation() bool {
+	return true
+}
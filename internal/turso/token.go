@@ -0,0 +1,141 @@
+package turso
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// refreshSkew is how far ahead of a JWT's expiry we proactively refresh it,
+// so a long-running command never races the server's own clock.
+const refreshSkew = 30 * time.Second
+
+// Token is a bearer credential together with everything needed to renew it.
+// It mirrors golang.org/x/oauth2.Token closely enough that the naming will
+// be familiar to anyone who has used that package.
+type Token struct {
+	AccessToken  string
+	RefreshToken string
+	Expiry       time.Time
+}
+
+func (t *Token) expired() bool {
+	if t.Expiry.IsZero() {
+		return false
+	}
+	return time.Now().Add(refreshSkew).After(t.Expiry)
+}
+
+// TokenSource mirrors golang.org/x/oauth2.TokenSource: Token returns a
+// credential that is valid for immediate use, transparently refreshing it
+// first if it is about to expire.
+type TokenSource interface {
+	Token() (*Token, error)
+}
+
+// forceRefreshingTokenSource is implemented by token sources that can renew
+// themselves unconditionally, bypassing whatever locally-parsed expiry
+// Token() would otherwise trust. Client.do uses it to recover from a 401
+// that a locally-valid token didn't see coming; apiKeyCredential doesn't
+// implement it, since there's nothing for the server to have expired.
+type forceRefreshingTokenSource interface {
+	ForceRefresh() (*Token, error)
+}
+
+// StaticToken is a TokenSource that never refreshes, for credentials that
+// have no concept of expiry (e.g. API keys).
+type StaticToken string
+
+func (t StaticToken) Token() (*Token, error) {
+	return &Token{AccessToken: string(t)}, nil
+}
+
+// persister is implemented by internal/settings.ProfileStore. It is declared
+// here, rather than imported, so that internal/turso does not depend on
+// internal/settings.
+type persister interface {
+	SetToken(string) error
+	SetRefreshToken(string) error
+}
+
+// jwtTokenSource renews an expired JWT by POSTing its refresh token to
+// /v2/auth/refresh, persisting whatever comes back so the next command
+// doesn't have to refresh again.
+type jwtTokenSource struct {
+	client *Client
+	store  persister
+
+	token        string
+	refreshToken string
+}
+
+func (s *jwtTokenSource) Token() (*Token, error) {
+	expiry, err := parseJwtExpiry(s.token)
+	if err == nil && !(&Token{Expiry: expiry}).expired() {
+		return &Token{AccessToken: s.token, RefreshToken: s.refreshToken, Expiry: expiry}, nil
+	}
+
+	return s.refresh()
+}
+
+// ForceRefresh renews the token unconditionally, ignoring the locally-parsed
+// expiry. It exists for the case where the server has already rejected the
+// token with a 401 even though our local check thought it was still good:
+// calling Token() again there would just hand back the same stale token.
+func (s *jwtTokenSource) ForceRefresh() (*Token, error) {
+	return s.refresh()
+}
+
+func (s *jwtTokenSource) refresh() (*Token, error) {
+	if len(s.refreshToken) == 0 {
+		// No way to refresh; hand back what we have and let the caller
+		// surface the eventual 401 from the server.
+		return &Token{AccessToken: s.token}, nil
+	}
+
+	token, refreshToken, err := s.client.RefreshToken(s.refreshToken)
+	if err != nil {
+		return nil, fmt.Errorf("could not refresh token: %w", err)
+	}
+
+	s.token = token
+	s.refreshToken = refreshToken
+
+	if err := s.store.SetToken(token); err != nil {
+		return nil, fmt.Errorf("could not persist refreshed token: %w", err)
+	}
+	if err := s.store.SetRefreshToken(refreshToken); err != nil {
+		return nil, fmt.Errorf("could not persist refreshed token: %w", err)
+	}
+
+	newExpiry, _ := parseJwtExpiry(token)
+	return &Token{AccessToken: token, RefreshToken: refreshToken, Expiry: newExpiry}, nil
+}
+
+// parseJwtExpiry reads the `exp` claim out of a JWT without verifying its
+// signature, so checking expiry never costs a round trip to the server.
+func parseJwtExpiry(token string) (time.Time, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, fmt.Errorf("not a JWT")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("could not decode JWT payload: %w", err)
+	}
+
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return time.Time{}, fmt.Errorf("could not parse JWT claims: %w", err)
+	}
+	if claims.Exp == 0 {
+		return time.Time{}, fmt.Errorf("JWT has no exp claim")
+	}
+
+	return time.Unix(claims.Exp, 0), nil
+}
@@ -0,0 +1,84 @@
+package turso
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func mustSignJwt(t *testing.T, claims interface{}) string {
+	t.Helper()
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatalf("could not marshal claims: %v", err)
+	}
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`))
+	body := base64.RawURLEncoding.EncodeToString(payload)
+	return header + "." + body + ".sig"
+}
+
+func TestParseJwtExpiry(t *testing.T) {
+	exp := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	token := mustSignJwt(t, struct {
+		Exp int64 `json:"exp"`
+	}{Exp: exp.Unix()})
+
+	got, err := parseJwtExpiry(token)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !got.Equal(exp) {
+		t.Errorf("got expiry %v, want %v", got, exp)
+	}
+}
+
+func TestParseJwtExpiry_NotThreeParts(t *testing.T) {
+	if _, err := parseJwtExpiry("not-a-jwt"); err == nil {
+		t.Error("expected error for a token without three dot-separated parts")
+	}
+	if _, err := parseJwtExpiry("a.b.c.d"); err == nil {
+		t.Error("expected error for a token with too many parts")
+	}
+}
+
+func TestParseJwtExpiry_BadBase64(t *testing.T) {
+	if _, err := parseJwtExpiry("a.not valid base64!!.c"); err == nil {
+		t.Error("expected error for a payload that isn't raw-url-base64")
+	}
+}
+
+func TestParseJwtExpiry_MissingOrZeroExp(t *testing.T) {
+	noExp := mustSignJwt(t, struct{}{})
+	if _, err := parseJwtExpiry(noExp); err == nil {
+		t.Error("expected error for a token with no exp claim")
+	}
+
+	zeroExp := mustSignJwt(t, struct {
+		Exp int64 `json:"exp"`
+	}{Exp: 0})
+	if _, err := parseJwtExpiry(zeroExp); err == nil {
+		t.Error("expected error for a token with exp: 0")
+	}
+}
+
+func TestTokenExpired(t *testing.T) {
+	cases := []struct {
+		name   string
+		expiry time.Time
+		want   bool
+	}{
+		{"zero expiry never expires", time.Time{}, false},
+		{"well within the skew window", time.Now().Add(refreshSkew / 2), true},
+		{"just past expiry", time.Now().Add(-time.Second), true},
+		{"comfortably in the future", time.Now().Add(refreshSkew * 10), false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			token := &Token{Expiry: c.expiry}
+			if got := token.expired(); got != c.want {
+				t.Errorf("expired() = %v, want %v", got, c.want)
+			}
+		})
+	}
+}